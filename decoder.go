@@ -3,6 +3,7 @@ package json
 import (
 	"encoding/json"
 	"io"
+	"sync/atomic"
 )
 
 type IDecoder interface {
@@ -11,21 +12,21 @@ type IDecoder interface {
 
 type DecoderFactory func(io.Reader) IDecoder
 
-var decoderFactory DecoderFactory
+var decoderFactory atomic.Value
 
 // DefaultDecoder Initialize the package with the standard library's JSON encoder by default.
 func DefaultDecoder() {
-	decoderFactory = func(w io.Reader) IDecoder {
+	decoderFactory.Store(DecoderFactory(func(w io.Reader) IDecoder {
 		return json.NewDecoder(w)
-	}
+	}))
 }
 
 // SetDecoder allows you to set a custom encoder factory.
 func SetDecoder(factory DecoderFactory) {
-	decoderFactory = factory
+	decoderFactory.Store(factory)
 }
 
 // NewDecoder creates a new encoder using the currently set encoder factory.
 func NewDecoder(w io.Reader) IDecoder {
-	return decoderFactory(w)
+	return decoderFactory.Load().(DecoderFactory)(w)
 }
@@ -3,6 +3,7 @@ package json
 import (
 	"encoding/json"
 	"io"
+	"sync/atomic"
 )
 
 type IEncoder interface {
@@ -11,21 +12,21 @@ type IEncoder interface {
 
 type EncoderFactory func(io.Writer) IEncoder
 
-var encoderFactory EncoderFactory
+var encoderFactory atomic.Value
 
 // DefaultEncoder Initialize the package with the standard library's JSON encoder by default.
 func DefaultEncoder() {
-	encoderFactory = func(w io.Writer) IEncoder {
+	encoderFactory.Store(EncoderFactory(func(w io.Writer) IEncoder {
 		return json.NewEncoder(w)
-	}
+	}))
 }
 
 // SetEncoder allows you to set a custom encoder factory.
 func SetEncoder(factory EncoderFactory) {
-	encoderFactory = factory
+	encoderFactory.Store(factory)
 }
 
 // NewEncoder creates a new encoder using the currently set encoder factory.
 func NewEncoder(w io.Writer) IEncoder {
-	return encoderFactory(w)
+	return encoderFactory.Load().(EncoderFactory)(w)
 }
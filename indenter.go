@@ -0,0 +1,37 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync/atomic"
+)
+
+type Indenter func(dst *bytes.Buffer, src []byte, prefix, indent string) error
+
+type Compactor func(dst *bytes.Buffer, src []byte) error
+
+var (
+	currentIndenter  atomic.Value
+	currentCompactor atomic.Value
+)
+
+func DefaultIndenter() {
+	SetIndenter(json.Indent)
+	SetCompactor(json.Compact)
+}
+
+func SetIndenter(i Indenter) {
+	currentIndenter.Store(i)
+}
+
+func SetCompactor(c Compactor) {
+	currentCompactor.Store(c)
+}
+
+func indenter(dst *bytes.Buffer, src []byte, prefix, indent string) error {
+	return currentIndenter.Load().(Indenter)(dst, src, prefix, indent)
+}
+
+func compactor(dst *bytes.Buffer, src []byte) error {
+	return currentCompactor.Load().(Compactor)(dst, src)
+}
@@ -1,9 +1,11 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -18,6 +20,7 @@ func init() {
 	DefaultUnmarshaler()
 	DefaultDecoder()
 	DefaultEncoder()
+	DefaultIndenter()
 }
 
 func unmarshalHelper(data json.RawMessage, field reflect.Value) error {
@@ -199,6 +202,29 @@ func Marshal(data any) ([]byte, error) {
 	return marshaler(data)
 }
 
+// MarshalIndent is like Marshal but applies Indent to format the output.
+func MarshalIndent(data any, prefix, indent string) ([]byte, error) {
+	b, err := marshaler(data)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := indenter(&buf, b, prefix, indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Compact appends to dst the JSON-encoded src with insignificant space characters elided.
+func Compact(dst *bytes.Buffer, src []byte) error {
+	return compactor(dst, src)
+}
+
+// Indent appends to dst an indented form of the JSON-encoded src.
+func Indent(dst *bytes.Buffer, src []byte, prefix, indent string) error {
+	return indenter(dst, src, prefix, indent)
+}
+
 func Unmarshal(data []byte, dst any, scheme ...[]byte) error {
 	if reflect.ValueOf(dst).Kind() != reflect.Ptr {
 		return errors.New("dst is not pointer type")
@@ -214,6 +240,49 @@ func Unmarshal(data []byte, dst any, scheme ...[]byte) error {
 	return rs.ValidateAndUnmarshalJSON(data, dst)
 }
 
+// UnmarshalStrict unmarshals data into dst using the configured decoder,
+// rejecting any object keys that do not map to a field of dst and any
+// input left after the first JSON value.
+func UnmarshalStrict(data []byte, dst any) error {
+	if reflect.ValueOf(dst).Kind() != reflect.Ptr {
+		return errors.New("dst is not pointer type")
+	}
+	dec := NewDecoder(bytes.NewReader(data))
+	strict, ok := dec.(interface{ DisallowUnknownFields() })
+	if !ok {
+		return errors.New("decoder does not support DisallowUnknownFields")
+	}
+	strict.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return err
+	}
+	var extra any
+	if err := dec.Decode(&extra); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// unmarshalNumber unmarshals data into v using the configured decoder with
+// UseNumber, so numbers keep their original text and large integers are not
+// rounded through float64.
+func unmarshalNumber(data []byte, v any) error {
+	dec := NewDecoder(bytes.NewReader(data))
+	num, ok := dec.(interface{ UseNumber() })
+	if !ok {
+		return errors.New("decoder does not support UseNumber")
+	}
+	num.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	var extra any
+	if err := dec.Decode(&extra); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
 func FixAndUnmarshal(data []byte, dst any, scheme ...[]byte) error {
 	if reflect.ValueOf(dst).Kind() != reflect.Ptr {
 		return errors.New("dst is not pointer type")
@@ -249,6 +318,11 @@ func IsValid(s string) bool {
 	return sjson.Valid(s)
 }
 
+// Valid reports whether data is a valid JSON encoding.
+func Valid(data []byte) bool {
+	return sjson.ValidBytes(data)
+}
+
 var re = regexp.MustCompile(`([{,])\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*:`)
 
 func Fix(input string) (string, error) {
@@ -1,6 +1,10 @@
 package json_test
 
 import (
+	"bytes"
+	stdjson "encoding/json"
+	"io"
+	"sync"
 	"testing"
 
 	"github.com/oarkflow/json"
@@ -25,3 +29,254 @@ func BenchmarkIs(b *testing.B) {
 		})
 	}
 }
+
+func TestUnmarshalStrict(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	var u user
+	if err := json.UnmarshalStrict([]byte(`{"name":"John"}`), &u); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.Name != "John" {
+		t.Fatalf("expected name John, got %q", u.Name)
+	}
+	if err := json.UnmarshalStrict([]byte(`{"name":"John","age":30}`), &u); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+	for _, data := range []string{`{"name":"a"} {"name":"b"}`, `{"name":"a"}garbage`, `{"name":"a"}}`} {
+		if err := json.UnmarshalStrict([]byte(data), &u); err == nil {
+			t.Errorf("%s: expected error for trailing data", data)
+		}
+	}
+	if err := json.UnmarshalStrict([]byte("{\"name\":\"a\"}\n"), &u); err != nil {
+		t.Errorf("unexpected error for trailing whitespace: %v", err)
+	}
+}
+
+func TestSetMarshalerConcurrent(t *testing.T) {
+	defer func() {
+		json.DefaultMarshaler()
+		json.DefaultUnmarshaler()
+		json.DefaultDecoder()
+		json.DefaultEncoder()
+		json.DefaultIndenter()
+	}()
+	use := []func() error{
+		func() error {
+			_, err := json.Marshal(map[string]any{"id": 1})
+			return err
+		},
+		func() error {
+			var v map[string]any
+			return json.Unmarshal([]byte(`{"id":1}`), &v)
+		},
+		func() error {
+			var v map[string]any
+			return json.NewDecoder(bytes.NewReader([]byte(`{"id":1}`))).Decode(&v)
+		},
+		func() error {
+			return json.NewEncoder(&bytes.Buffer{}).Encode(map[string]any{"id": 1})
+		},
+		func() error {
+			var dst bytes.Buffer
+			if err := json.Indent(&dst, []byte(`{"id":1}`), "", " "); err != nil {
+				return err
+			}
+			return json.Compact(&dst, []byte(`{"id": 1}`))
+		},
+	}
+	swap := []func(){
+		func() { json.SetMarshaler(stdjson.Marshal) },
+		func() { json.SetUnmarshaler(stdjson.Unmarshal) },
+		func() {
+			json.SetDecoder(func(r io.Reader) json.IDecoder { return stdjson.NewDecoder(r) })
+		},
+		func() {
+			json.SetEncoder(func(w io.Writer) json.IEncoder { return stdjson.NewEncoder(w) })
+		},
+		func() {
+			json.SetIndenter(stdjson.Indent)
+			json.SetCompactor(stdjson.Compact)
+		},
+	}
+	var wg sync.WaitGroup
+	for i := range use {
+		for k := 0; k < 4; k++ {
+			wg.Add(2)
+			go func(use func() error) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if err := use(); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}(use[i])
+			go func(swap func()) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					swap()
+				}
+			}(swap[i])
+		}
+	}
+	wg.Wait()
+}
+
+var formatDocs = []string{
+	`{"name": "John", "age": 30, "tags": ["a", "b"]}`,
+	`[ {"id": 1}, {"id": 2, "nested": {"ok": true, "v": null}} ]`,
+	`"plain"`,
+	`{}`,
+}
+
+func TestCompact(t *testing.T) {
+	for _, doc := range formatDocs {
+		var got, want bytes.Buffer
+		if err := json.Compact(&got, []byte(doc)); err != nil {
+			t.Fatalf("compact %s: %v", doc, err)
+		}
+		if err := stdjson.Compact(&want, []byte(doc)); err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != want.String() {
+			t.Errorf("compact %s: got %s, want %s", doc, got.String(), want.String())
+		}
+	}
+}
+
+func TestIndent(t *testing.T) {
+	for _, doc := range formatDocs {
+		var got, want bytes.Buffer
+		if err := json.Indent(&got, []byte(doc), ">", "  "); err != nil {
+			t.Fatalf("indent %s: %v", doc, err)
+		}
+		if err := stdjson.Indent(&want, []byte(doc), ">", "  "); err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != want.String() {
+			t.Errorf("indent %s: got %s, want %s", doc, got.String(), want.String())
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	if !json.Valid([]byte(`{"a":[1,2]}`)) {
+		t.Error("expected valid document")
+	}
+	if json.Valid([]byte(`{a:1}`)) {
+		t.Error("expected invalid document")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := []byte(`{"name":"app","db":{"host":"localhost","port":5432},"tags":["a"],"debug":{"level":1}}`)
+	overlay := []byte(`{"db":{"host":"db.internal"},"tags":["b"],"debug":false,"extra":null}`)
+
+	got, err := json.Merge(base, overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"db":{"host":"db.internal","port":5432},"debug":false,"extra":null,"name":"app","tags":["b"]}`
+	if string(got) != want {
+		t.Errorf("replace mode: got %s, want %s", got, want)
+	}
+
+	got, err = json.Merge(base, overlay, json.ArrayConcat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{"db":{"host":"db.internal","port":5432},"debug":false,"extra":null,"name":"app","tags":["a","b"]}`
+	if string(got) != want {
+		t.Errorf("concat mode: got %s, want %s", got, want)
+	}
+
+	got, err = json.Merge([]byte(`{"a":[1]}`), []byte(`{"a":{"b":1}}`), json.ArrayConcat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `{"a":{"b":1}}` {
+		t.Errorf("type conflict: got %s", got)
+	}
+
+	got, err = json.Merge([]byte(`{"id":10000000000000001,"ratio":1.50}`), []byte(`{"name":"x"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `{"id":10000000000000001,"name":"x","ratio":1.50}` {
+		t.Errorf("numbers changed: got %s", got)
+	}
+
+	if _, err := json.Merge([]byte(`{`), overlay); err == nil {
+		t.Error("expected error for invalid base")
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	doc := []byte(`{"a":{"b":1},"list":[1,2,3],"x~y":true}`)
+	tests := []struct {
+		patch string
+		want  string
+	}{
+		{`[{"op":"add","path":"/a/c","value":2}]`, `{"a":{"b":1,"c":2},"list":[1,2,3],"x~y":true}`},
+		{`[{"op":"add","path":"/list/1","value":9},{"op":"add","path":"/list/-","value":4}]`, `{"a":{"b":1},"list":[1,9,2,3,4],"x~y":true}`},
+		{`[{"op":"remove","path":"/list/0"},{"op":"remove","path":"/x~0y"}]`, `{"a":{"b":1},"list":[2,3]}`},
+		{`[{"op":"replace","path":"/a/b","value":[true]}]`, `{"a":{"b":[true]},"list":[1,2,3],"x~y":true}`},
+		{`[{"op":"move","from":"/a/b","path":"/list/0"}]`, `{"a":{},"list":[1,1,2,3],"x~y":true}`},
+		{`[{"op":"copy","from":"/a","path":"/c"}]`, `{"a":{"b":1},"c":{"b":1},"list":[1,2,3],"x~y":true}`},
+		{`[{"op":"test","path":"/a","value":{"b":1.0}}]`, `{"a":{"b":1},"list":[1,2,3],"x~y":true}`},
+		{`[{"op":"test","path":"/list","value":[1,2,3]},{"op":"add","path":"/id","value":10000000000000001}]`, `{"a":{"b":1},"id":10000000000000001,"list":[1,2,3],"x~y":true}`},
+	}
+	for _, tt := range tests {
+		got, err := json.ApplyPatch(doc, []byte(tt.patch))
+		if err != nil {
+			t.Errorf("%s: %v", tt.patch, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.patch, got, tt.want)
+		}
+	}
+
+	failing := []string{
+		`[{"op":"test","path":"/a/b","value":2}]`,
+		`[{"op":"remove","path":"/missing"}]`,
+		`[{"op":"replace","path":"/list/3","value":1}]`,
+		`[{"op":"move","from":"/a","path":"/a/b"}]`,
+		`[{"op":"unknown","path":"/a"}]`,
+		`[{"op":"add","path":"/list/+1","value":1}]`,
+		`[{"op":"add","path":"/id","value":10000000000000001},{"op":"test","path":"/id","value":10000000000000000}]`,
+		`[{"op":"test","path":"/x~0y","value":"true"}]`,
+		`[{"op":"remove","path":"/list/-0"}]`,
+	}
+	for _, patch := range failing {
+		if _, err := json.ApplyPatch(doc, []byte(patch)); err == nil {
+			t.Errorf("%s: expected error", patch)
+		}
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b","c":{"d":"e","f":"g"}}`, `{"a":"z","c":{"f":null}}`, `{"a":"z","c":{"d":"e"}}`},
+		{`{"a":{"b":{"c":1}},"keep":true}`, `{"a":{"b":{"d":2}}}`, `{"a":{"b":{"c":1,"d":2}},"keep":true}`},
+		{`{"a":[1,2,3]}`, `{"a":[4]}`, `{"a":[4]}`},
+		{`{"a":"b"}`, `{"a":{"c":null,"d":1}}`, `{"a":{"d":1}}`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`[1,2]`, `{"a":1}`, `{"a":1}`},
+		{`{"id":10000000000000001,"n":1}`, `{"n":9007199254740993}`, `{"id":10000000000000001,"n":9007199254740993}`},
+	}
+	for _, tt := range tests {
+		got, err := json.ApplyMergePatch([]byte(tt.doc), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("%s + %s: %v", tt.doc, tt.patch, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s + %s: got %s, want %s", tt.doc, tt.patch, got, tt.want)
+		}
+	}
+}
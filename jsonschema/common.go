@@ -19,6 +19,7 @@ type Error struct {
 type ValidateCtx struct {
 	errors []Error
 	root   Validator
+	defs   map[string]Validator
 }
 
 func (v *ValidateCtx) AddError(e Error) {
@@ -36,7 +37,7 @@ func (v *ValidateCtx) AddErrors(e ...Error) {
 }
 
 func (v *ValidateCtx) Clone() *ValidateCtx {
-	return &ValidateCtx{root: v.root}
+	return &ValidateCtx{root: v.root, defs: v.defs}
 }
 
 type Validator interface {
@@ -45,6 +46,85 @@ type Validator interface {
 
 type NewValidatorFunc func(i any, path string, parent Validator) (Validator, error)
 
+// JSONPointerOf converts a dotted error path like $.foo[0].bar into an
+// RFC 6901 JSON Pointer like /foo/0/bar. Wildcards become "*" tokens and
+// bracketed keys like $['a.b'] keep their original text.
+func JSONPointerOf(path string) string {
+	path = strings.TrimPrefix(path, "$")
+	sb := strings.Builder{}
+	sb.Grow(len(path))
+	for len(path) > 0 {
+		var tkn string
+		switch {
+		case strings.HasPrefix(path, "['"):
+			tkn, path = unquoteKey(path[2:])
+		case path[0] == '.':
+			end := strings.IndexAny(path[1:], ".[{")
+			if end < 0 {
+				end = len(path) - 1
+			}
+			tkn, path = path[1:end+1], path[end+1:]
+		case path[0] == '[' || path[0] == '{':
+			closing := byte(']')
+			if path[0] == '{' {
+				closing = '}'
+			}
+			end := strings.IndexByte(path, closing)
+			if end < 0 {
+				end = len(path)
+				tkn, path = path[1:], ""
+			} else {
+				tkn, path = path[1:end], path[end+1:]
+			}
+		default:
+			end := strings.IndexAny(path, ".[{")
+			if end < 0 {
+				end = len(path)
+			}
+			tkn, path = path[:end], path[end:]
+		}
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(tkn))
+	}
+	return sb.String()
+}
+
+// keyPath appends key to the dotted path. Keys that contain path syntax are
+// written in bracket notation like $['a.b'], so JSONPointerOf can recover
+// the original key.
+func keyPath(path, key string) string {
+	if key != "" && !strings.ContainsAny(key, ".[]{}'\\") {
+		return appendString(path, ".", key)
+	}
+	return appendString(path, "['", keyQuoter.Replace(key), "']")
+}
+
+// unquoteKey reads a bracketed key written by keyPath, starting after its
+// opening "['", and returns the key and the rest of the path.
+func unquoteKey(path string) (string, string) {
+	sb := strings.Builder{}
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			if i+1 < len(path) {
+				i++
+				sb.WriteByte(path[i])
+			}
+		case '\'':
+			return sb.String(), strings.TrimPrefix(path[i+1:], "]")
+		default:
+			sb.WriteByte(path[i])
+		}
+	}
+	return sb.String(), ""
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+	keyQuoter        = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+)
+
 func appendString(s ...string) string {
 	sb := strings.Builder{}
 	for _, str := range s {
@@ -130,6 +210,82 @@ func Equal(a, b any) bool {
 	return StringOf(a) == StringOf(b)
 }
 
+// equalValue reports whether a and b are deeply equal, comparing numbers by
+// value regardless of their Go type.
+func equalValue(a, b any) bool {
+	a, b = normalizeValue(a), normalizeValue(b)
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, val := range av {
+			other, ok := bv[key]
+			if !ok || !equalValue(val, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equalValue(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if af, ok := valueOfFloat(a); ok {
+		bf, ok := valueOfFloat(b)
+		return ok && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// normalizeValue maps reflected values onto their decoded JSON form, so named
+// string types compare as strings and typed slices and maps compare as []any
+// and map[string]any.
+func normalizeValue(value any) any {
+	switch value.(type) {
+	case nil, string, bool, float64, map[string]any, []any:
+		return value
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Slice, reflect.Array:
+		arr := make([]any, v.Len())
+		for i := range arr {
+			arr[i] = v.Index(i).Interface()
+		}
+		return arr
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = iter.Value().Interface()
+		}
+		return m
+	}
+	return v.Interface()
+}
+
 func desc(i any) string {
 	ty := reflect.TypeOf(i)
 	return fmt.Sprintf("value:%v,type:%s", i, ty.String())
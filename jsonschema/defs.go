@@ -1,6 +1,10 @@
 package jsonschema
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 func init() {
 	RegisterValidator("$defs", newDefs)
@@ -11,6 +15,16 @@ type Children interface {
 	GetChild(path string) Validator
 }
 
+// childAt returns the schema at the array index path of vs, like the "0" of
+// "#/anyOf/0".
+func childAt(vs []Validator, path string) Validator {
+	idx, err := strconv.Atoi(path)
+	if err != nil || idx < 0 || idx >= len(vs) {
+		return nil
+	}
+	return vs[idx]
+}
+
 type defs struct {
 	schemas map[string]Validator
 }
@@ -42,3 +56,49 @@ var newDefs NewValidatorFunc = func(i any, path string, parent Validator) (Valid
 	}
 	return def, nil
 }
+
+// indexDefs records every definitions and $defs entry below v in table,
+// keyed by its local reference like "#/definitions/name".
+func indexDefs(prefix string, v Validator, table map[string]Validator) {
+	ap, ok := v.(*ArrProp)
+	if !ok {
+		return
+	}
+	for _, key := range []string{"definitions", "$defs"} {
+		d, ok := ap.Get(key).(*defs)
+		if !ok {
+			continue
+		}
+		for name, sub := range d.schemas {
+			ref := appendString(prefix, "/", key, "/", pointerEscaper.Replace(name))
+			table[ref] = sub
+			indexDefs(ref, sub, table)
+		}
+	}
+}
+
+// checkRefCycles rejects definitions that only refer to each other, like
+// a -> b -> a, which would never consume any input while validating.
+func checkRefCycles(table map[string]Validator) error {
+	for name := range table {
+		chain := []string{name}
+		seen := map[string]bool{name: true}
+		for cur := table[name]; ; {
+			ap, ok := cur.(*ArrProp)
+			if !ok {
+				break
+			}
+			r, ok := ap.Get("$ref").(*ref)
+			if !ok {
+				break
+			}
+			chain = append(chain, r.ref)
+			if seen[r.ref] {
+				return fmt.Errorf("cyclic $ref: %s", strings.Join(chain, " -> "))
+			}
+			seen[r.ref] = true
+			cur = table[r.ref]
+		}
+	}
+	return nil
+}
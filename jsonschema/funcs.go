@@ -3,6 +3,7 @@ package jsonschema
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 func init() {
@@ -21,6 +22,11 @@ func init() {
 	SetFunc("sprintf", funcSprintf)
 	SetFunc("or", funcOr)
 	SetFunc("delete", funcDelete)
+	SetFunc("concat", funcConcat)
+	SetFunc("now", funcNow)
+	SetFunc("upper", funcUpper)
+	SetFunc("lower", funcLower)
+	SetFunc("coalesce", funcCoalesce)
 }
 
 func funcAppend(ctx Context, args ...Value) any {
@@ -164,3 +170,65 @@ func funcDelete(ctx Context, args ...Value) any {
 	}
 	return nil
 }
+
+// funcConcat joins array arguments into one array, or concatenates the
+// arguments as strings when any of them is not an array.
+func funcConcat(ctx Context, args ...Value) any {
+	vals := make([]any, len(args))
+	arrays := true
+	for i, arg := range args {
+		vals[i] = arg.Get(ctx)
+		if _, ok := vals[i].([]any); !ok {
+			arrays = false
+		}
+	}
+	if !arrays {
+		bf := strings.Builder{}
+		for _, v := range vals {
+			bf.WriteString(StringOf(v))
+		}
+		return bf.String()
+	}
+	res := []any{}
+	for _, v := range vals {
+		res = append(res, v.([]any)...)
+	}
+	return res
+}
+
+// funcNow returns the current time formatted with the layout given as the
+// first argument, or RFC3339 by default.
+func funcNow(ctx Context, args ...Value) any {
+	layout := time.RFC3339
+	if len(args) > 0 {
+		if l := StringOf(args[0].Get(ctx)); l != "" {
+			layout = l
+		}
+	}
+	return time.Now().Format(layout)
+}
+
+func funcUpper(ctx Context, args ...Value) any {
+	if len(args) < 1 {
+		return ""
+	}
+	return strings.ToUpper(StringOf(args[0].Get(ctx)))
+}
+
+func funcLower(ctx Context, args ...Value) any {
+	if len(args) < 1 {
+		return ""
+	}
+	return strings.ToLower(StringOf(args[0].Get(ctx)))
+}
+
+// funcCoalesce returns the first argument that is not null. Unlike or,
+// empty strings are kept.
+func funcCoalesce(ctx Context, args ...Value) any {
+	for _, arg := range args {
+		if val := arg.Get(ctx); val != nil {
+			return val
+		}
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ func init() {
 type ref struct {
 	path   []string
 	jp     string
+	ref    string
 	parent Validator
 }
 
@@ -20,6 +21,10 @@ func (r *ref) isSelf(n Validator) bool {
 }
 
 func (r *ref) Validate(c *ValidateCtx, value any) {
+	if node := c.defs[r.ref]; node != nil {
+		r.validate(c, node, value)
+		return
+	}
 	node := c.root
 	for _, pth := range r.path {
 		switch nv := node.(type) {
@@ -43,10 +48,22 @@ func (r *ref) Validate(c *ValidateCtx, value any) {
 		})
 		return
 	}
-	cc := c.Clone()
-	if node != nil {
-		node.Validate(cc, value)
+	if node == nil {
+		// only local pointers are resolved here, remote refs are left alone
+		if strings.HasPrefix(r.ref, "#") {
+			c.AddError(Error{
+				Path: r.jp,
+				Info: "cannot resolve $ref: " + r.ref,
+			})
+		}
+		return
 	}
+	r.validate(c, node, value)
+}
+
+func (r *ref) validate(c *ValidateCtx, node Validator, value any) {
+	cc := c.Clone()
+	node.Validate(cc, value)
 	if len(cc.errors) > 0 {
 		for i, e := range cc.errors {
 			if len(e.Path) >= 1 {
@@ -67,15 +84,19 @@ var newRef NewValidatorFunc = func(i any, path string, parent Validator) (Valida
 	if !ok {
 		return nil, fmt.Errorf("%s.$ref should be string", path)
 	}
-	str = strings.TrimPrefix(str, "#")
-	str = strings.TrimPrefix(str, "/")
 	ref := &ref{
 		jp:     path,
+		ref:    str,
 		parent: parent,
 	}
+	str = strings.TrimPrefix(str, "#")
+	str = strings.TrimPrefix(str, "/")
 	if str == "" {
 		return ref, nil
 	}
 	ref.path = strings.Split(str, "/")
+	for i, tkn := range ref.path {
+		ref.path[i] = pointerUnescaper.Replace(tkn)
+	}
 	return ref, nil
 }
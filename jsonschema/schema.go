@@ -13,16 +13,16 @@ import (
 type Schema struct {
 	prop Validator
 	i    any
+	defs map[string]Validator
+
+	pointerErrorPath bool
 }
 
 func NewSchema(i map[string]any) (*Schema, error) {
 	s := &Schema{}
-	s.i = i
-	p, err := NewProp(i, "$")
-	if err != nil {
+	if err := s.compile(i); err != nil {
 		return nil, err
 	}
-	s.prop = p
 	return s, nil
 }
 
@@ -39,15 +39,48 @@ func (s *Schema) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &i); err != nil {
 		return err
 	}
-	s.i = i
+	return s.compile(i)
+}
+
+func (s *Schema) compile(i any) error {
 	p, err := NewProp(i, "$")
 	if err != nil {
 		return err
 	}
+	defs := map[string]Validator{}
+	indexDefs("#", p, defs)
+	if err := checkRefCycles(defs); err != nil {
+		return err
+	}
+	s.i = i
 	s.prop = p
+	s.defs = defs
 	return nil
 }
 
+// Definition returns the compiled schema of a definitions or $defs entry by
+// its local reference, like "#/definitions/name" or "#/$defs/a/$defs/b".
+func (s *Schema) Definition(ref string) Validator {
+	return s.defs[ref]
+}
+
+// SetPointerErrorPath makes validation errors of this schema report RFC 6901
+// JSON Pointers (like /foo/bar) in Error.Path instead of dotted paths (like
+// $.foo.bar). It should be set before the schema is shared between goroutines.
+func (s *Schema) SetPointerErrorPath(v bool) {
+	s.pointerErrorPath = v
+}
+
+func (s *Schema) outputErrors(errs []Error) []Error {
+	if !s.pointerErrorPath {
+		return errs
+	}
+	for i := range errs {
+		errs[i].Path = JSONPointerOf(errs[i].Path)
+	}
+	return errs
+}
+
 func (s *Schema) MarshalJSON() (b []byte, err error) {
 	data, err := json.Marshal(s.i)
 	if err != nil {
@@ -67,6 +100,7 @@ var (
 func (s *Schema) ValidateObject(i any) error {
 	c := vctPool.Get().(*ValidateCtx)
 	c.root = s.prop
+	c.defs = s.defs
 	c.errors = c.errors[:0]
 	defer vctPool.Put(c)
 
@@ -74,13 +108,14 @@ func (s *Schema) ValidateObject(i any) error {
 	if len(c.errors) == 0 {
 		return nil
 	}
-	return errors.New(errsToString(c.errors))
+	return errors.New(errsToString(s.outputErrors(c.errors)))
 }
 
 func (s *Schema) Validate(i any) error {
 
 	c := vctPool.Get().(*ValidateCtx)
 	c.root = s.prop
+	c.defs = s.defs
 	c.errors = c.errors[:0]
 	defer vctPool.Put(c)
 	ii, err := scaleObject(i)
@@ -91,7 +126,7 @@ func (s *Schema) Validate(i any) error {
 	if len(c.errors) == 0 {
 		return nil
 	}
-	return errors.New(errsToString(c.errors))
+	return errors.New(errsToString(s.outputErrors(c.errors)))
 }
 
 func (s *Schema) ValidateAndUnmarshalJSON(data []byte, template any) (err error) {
@@ -107,6 +142,35 @@ func (s *Schema) ValidateAndUnmarshalJSON(data []byte, template any) (err error)
 	return UnmarshalFromMap(i, template)
 }
 
+// ValidateCopy validates a deep copy of i and returns the copy with the
+// schema's constVal, default, replaceKey and formatVal changes applied.
+// Unlike Validate, the input is never modified, so the same value can be
+// validated from multiple goroutines. Structs and other reflected values
+// are converted to their JSON form first, so the copy is a map[string]any
+// or []any rather than the caller's type.
+func (s *Schema) ValidateCopy(i any) (any, error) {
+	ii, err := scaleObject(i)
+	if err != nil {
+		return nil, err
+	}
+	switch ii.(type) {
+	case map[string]any, []any, string, float64, bool, nil:
+	default:
+		data, err := json.Marshal(ii)
+		if err != nil {
+			return nil, err
+		}
+		if ii, err = scaleObject(data); err != nil {
+			return nil, err
+		}
+	}
+	cp := copyValue(ii)
+	if err := s.ValidateObject(cp); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
 func scaleObject(i any) (o any, err error) {
 	switch d := i.(type) {
 	case []byte:
@@ -126,10 +190,27 @@ func scaleObject(i any) (o any, err error) {
 	}
 }
 
+// ValidateError validates i like Validate and returns every failure as a
+// structured Error instead of a joined message. Malformed JSON input is
+// reported as a single error at the root path.
 func (s *Schema) ValidateError(i any) []Error {
-	c := &ValidateCtx{}
-	s.prop.Validate(c, i)
-	return c.errors
+	ii, err := scaleObject(i)
+	if err != nil {
+		return s.outputErrors([]Error{{Path: "$", Info: err.Error()}})
+	}
+	c := vctPool.Get().(*ValidateCtx)
+	c.root = s.prop
+	c.defs = s.defs
+	c.errors = c.errors[:0]
+	defer vctPool.Put(c)
+
+	s.prop.Validate(c, ii)
+	if len(c.errors) == 0 {
+		return nil
+	}
+	errs := make([]Error, len(c.errors))
+	copy(errs, c.errors)
+	return s.outputErrors(errs)
 }
 
 func (s *Schema) Bytes() []byte {
@@ -0,0 +1,422 @@
+package jsonschema_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/oarkflow/json/jsonschema"
+)
+
+func mustSchema(t *testing.T, s string) *jsonschema.Schema {
+	t.Helper()
+	sc, err := jsonschema.NewSchemaFromJSON([]byte(s))
+	if err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return sc
+}
+
+func TestPointerErrorPath(t *testing.T) {
+	sc := mustSchema(t, `{"properties":{"a":{"properties":{"b~c":{"type":"string"}}}}}`)
+	input := map[string]any{"a": map[string]any{"b~c": 1.0}}
+
+	errs := sc.ValidateError(input)
+	if len(errs) != 1 || errs[0].Path != "$.a.b~c" {
+		t.Fatalf("unexpected dotted errors: %+v", errs)
+	}
+
+	sc.SetPointerErrorPath(true)
+	errs = sc.ValidateError(input)
+	if len(errs) != 1 || errs[0].Path != "/a/b~0c" {
+		t.Fatalf("unexpected pointer errors: %+v", errs)
+	}
+	if got := jsonschema.JSONPointerOf("$[*].tags[2]"); got != "/*/tags/2" {
+		t.Fatalf("unexpected pointer: %s", got)
+	}
+
+	sc = mustSchema(t, `{"properties":{"a.b":{"type":"string"},"x[0]":{"properties":{"it's":{"type":"string"}}}}}`)
+	input = map[string]any{"a.b": 1.0, "x[0]": map[string]any{"it's": 1.0, "u/v": 1.0}}
+	errs = sc.ValidateError(input)
+	got := map[string]bool{}
+	for _, e := range errs {
+		got[e.Path] = true
+	}
+	if len(errs) != 3 || !got[`$['a.b']`] || !got[`$['x[0]']['it\'s']`] || !got[`$['x[0]'].u/v`] {
+		t.Fatalf("unexpected dotted errors: %+v", errs)
+	}
+	sc.SetPointerErrorPath(true)
+	errs = sc.ValidateError(input)
+	got = map[string]bool{}
+	for _, e := range errs {
+		got[e.Path] = true
+	}
+	if len(errs) != 3 || !got["/a.b"] || !got["/x[0]/it's"] || !got["/x[0]/u~1v"] {
+		t.Fatalf("unexpected pointer errors: %+v", errs)
+	}
+}
+
+func TestItemsReflectedSlice(t *testing.T) {
+	type tag struct {
+		Name string `json:"name"`
+	}
+	sc := mustSchema(t, `{"type":"array","items":{"type":"object","properties":{"name":{"type":"string","maxLength":3}}}}`)
+
+	errs := sc.ValidateError([]tag{{Name: "ok"}, {Name: "fine"}, {Name: "a"}, {Name: "too long"}})
+	if len(errs) != 2 || errs[0].Path != "$[1].name" || errs[1].Path != "$[3].name" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+
+	errs = sc.ValidateError([]any{map[string]any{"name": "a"}, map[string]any{"name": "long"}})
+	if len(errs) != 1 || errs[0].Path != "$[1].name" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestValidateCopy(t *testing.T) {
+	sc := mustSchema(t, `{"properties":{"name":{"type":"string"},"kind":{"constVal":"user"},"age":{"default":18}}}`)
+	input := map[string]any{"name": "John"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out, err := sc.ValidateCopy(input)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			m := out.(map[string]any)
+			if m["kind"] != "user" || m["name"] != "John" || m["age"] == nil {
+				t.Errorf("unexpected output: %v", m)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(input) != 1 {
+		t.Fatalf("input was modified: %v", input)
+	}
+
+	sc = mustSchema(t, `{"properties":{"opts":{"default":{"k":[1]}},"kind":{"constVal":{"v":"user"}}}}`)
+	first, err := sc.ValidateCopy(map[string]any{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := sc.ValidateCopy(map[string]any{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.(map[string]any)["opts"].(map[string]any)["k"] = "changed"
+	first.(map[string]any)["kind"].(map[string]any)["v"] = "changed"
+	opts := second.(map[string]any)["opts"].(map[string]any)
+	kind := second.(map[string]any)["kind"].(map[string]any)
+	if _, ok := opts["k"].([]any); !ok || kind["v"] != "user" {
+		t.Fatalf("outputs alias each other: %v", second)
+	}
+
+	type user struct {
+		Name string `json:"name"`
+		Kind string `json:"kind,omitempty"`
+		Age  int    `json:"age,omitempty"`
+	}
+	sc = mustSchema(t, `{"properties":{"name":{"type":"string"},"kind":{"constVal":"user"},"age":{"default":18}}}`)
+	u := &user{Name: "x"}
+	out, err := sc.ValidateCopy(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *u != (user{Name: "x"}) {
+		t.Fatalf("input was modified: %+v", *u)
+	}
+	m, ok := out.(map[string]any)
+	if !ok || m["name"] != "x" || m["kind"] != "user" || m["age"] == nil {
+		t.Fatalf("unexpected output: %#v", out)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	sc := mustSchema(t, `{"properties":{"email":{"format":"email"},"date":{"format":"date"},"id":{"format":"uuid"}}}`)
+	valid := map[string]any{"email": "john@example.com", "date": "2024-02-29", "id": "123e4567-e89b-12d3-a456-426614174000"}
+	if err := sc.Validate(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := sc.ValidateError(map[string]any{"email": "john", "date": "2024-13-01", "id": "123e4567"})
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %+v", errs)
+	}
+}
+
+func TestUnknownFieldInheritance(t *testing.T) {
+	strict := mustSchema(t, `{"additionalProperties":false,"properties":{"a":{"properties":{"b":{"type":"string"}}}}}`)
+	errs := strict.ValidateError(map[string]any{"a": map[string]any{"b": "x", "c": "y"}})
+	if len(errs) != 1 || errs[0].Path != "$.a.c" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestConstTyped(t *testing.T) {
+	type status string
+	type req struct {
+		Status status   `json:"status"`
+		Tags   []string `json:"tags"`
+	}
+	cases := []struct {
+		schema string
+		valid  []any
+		fail   []any
+	}{
+		{`{"const":true}`, []any{true}, []any{"true", 1.0}},
+		{`{"const":1}`, []any{1.0, 1, int64(1)}, []any{"1", true}},
+		{`{"enum":[1,2]}`, []any{2.0, int64(2), uint8(1)}, []any{"2", 3.0, true}},
+		{`{"enum":["a",{"k":[1]}]}`, []any{"a", status("a"), map[string]any{"k": []any{int64(1)}}}, []any{"k", map[string]any{"k": []any{2.0}}}},
+		{`{"const":{"a":[1,"x"]}}`, []any{map[string]any{"a": []any{1.0, "x"}}}, []any{"map[a:[1 x]]", map[string]any{"a": []any{1.0}}}},
+		{`{"properties":{"status":{"const":"active"},"tags":{"const":["a"]}}}`,
+			[]any{req{Status: "active", Tags: []string{"a"}}, &req{Status: "active", Tags: []string{"a"}}},
+			[]any{req{Status: "inactive", Tags: []string{"a"}}, req{Status: "active", Tags: []string{"a", "b"}}}},
+	}
+	for _, cs := range cases {
+		sc := mustSchema(t, cs.schema)
+		for _, v := range cs.valid {
+			if err := sc.ValidateObject(v); err != nil {
+				t.Errorf("%s: expected %#v to be valid, got %v", cs.schema, v, err)
+			}
+		}
+		for _, v := range cs.fail {
+			if err := sc.ValidateObject(v); err == nil {
+				t.Errorf("%s: expected %#v to be invalid", cs.schema, v)
+			}
+		}
+	}
+}
+
+func TestItemsCountReflected(t *testing.T) {
+	type post struct {
+		Tags []string `json:"tags" minItems:"1" maxItems:"2"`
+	}
+	sc, err := jsonschema.GenerateSchema(post{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.Validate(post{Tags: []string{"a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sc.Validate(post{Tags: []string{}}); err == nil {
+		t.Fatal("expected minItems error")
+	}
+	if err := sc.Validate(&post{Tags: []string{"a", "b", "c"}}); err == nil {
+		t.Fatal("expected maxItems error")
+	}
+}
+
+func TestValidateErrorRawJSON(t *testing.T) {
+	sc := mustSchema(t, `{"properties":{"user":{"properties":{"age":{"type":"integer"}},"required":["age"]}}}`)
+
+	errs := sc.ValidateError([]byte(`{"user":{"age":"ten"}}`))
+	if len(errs) != 1 || errs[0].Path != "$.user.age" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	errs = sc.ValidateError(`{"user":{}}`)
+	if len(errs) != 1 || errs[0].Path != "$.user.age" || errs[0].Info != "field is required" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	if errs := sc.ValidateError(`{"user":`); len(errs) != 1 || errs[0].Path != "$" {
+		t.Fatalf("expected parse error, got %+v", errs)
+	}
+	if errs := sc.ValidateError([]byte(`{"user":{"age":3}}`)); errs != nil {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestIntegerEnum(t *testing.T) {
+	sc := mustSchema(t, `{"properties":{"n":{"type":"integer","enum":[1,2,3]}}}`)
+	for _, v := range []any{int64(3), 2.0, int32(1)} {
+		if err := sc.ValidateObject(map[string]any{"n": v}); err != nil {
+			t.Errorf("%#v: unexpected error: %v", v, err)
+		}
+	}
+	for _, v := range []any{int64(4), "1"} {
+		if err := sc.ValidateObject(map[string]any{"n": v}); err == nil {
+			t.Errorf("%#v: expected error", v)
+		}
+	}
+}
+
+func TestBoundsNamedFloat(t *testing.T) {
+	type temp float64
+	sc := mustSchema(t, `{"minimum":-10,"maximum":40}`)
+	if err := sc.Validate(temp(21.5)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sc.Validate(temp(40.5)); err == nil {
+		t.Fatal("expected maximum error")
+	}
+	v := temp(-11)
+	if err := sc.Validate(&v); err == nil {
+		t.Fatal("expected minimum error")
+	}
+}
+
+func TestTupleAdditionalItems(t *testing.T) {
+	sc := mustSchema(t, `{"items":[{"type":"string"},{"type":"number"}],"additionalItems":{"type":"boolean"}}`)
+	if err := sc.Validate(`["a",1,true,false]`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := sc.ValidateError(`[1,"b",true,"c"]`)
+	if len(errs) != 3 || errs[0].Path != "$[0]" || errs[1].Path != "$[1]" || errs[2].Path != "$[3]" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+
+	closed := mustSchema(t, `{"items":[{"type":"string"},{"type":"number"}],"additionalItems":false}`)
+	if err := closed.Validate(`["a",1]`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := closed.ValidateError(`["a",1,true]`); len(errs) != 1 || errs[0].Path != "$" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestDeleteNestedAndWildcard(t *testing.T) {
+	sc := mustSchema(t, `{"delete":["address.secret","token_*","items.internal"],"additionalProperties":true}`)
+	input := map[string]any{
+		"name":        "John",
+		"token_a":     "x",
+		"token_b":     "y",
+		"address":     map[string]any{"city": "NYC", "secret": "s"},
+		"items":       []any{map[string]any{"id": 1.0, "internal": true}},
+		"tokenizer":   "kept",
+		"address.tag": "kept",
+	}
+	if err := sc.Validate(input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := input["token_a"]; ok {
+		t.Error("token_a should be deleted")
+	}
+	if _, ok := input["token_b"]; ok {
+		t.Error("token_b should be deleted")
+	}
+	if input["tokenizer"] != "kept" || input["address.tag"] != "kept" || input["name"] != "John" {
+		t.Errorf("unexpected deletion: %v", input)
+	}
+	address := input["address"].(map[string]any)
+	if _, ok := address["secret"]; ok || address["city"] != "NYC" {
+		t.Errorf("unexpected address: %v", address)
+	}
+	item := input["items"].([]any)[0].(map[string]any)
+	if _, ok := item["internal"]; ok || item["id"] != 1.0 {
+		t.Errorf("unexpected item: %v", item)
+	}
+}
+
+func TestSetValFuncs(t *testing.T) {
+	sc := mustSchema(t, `{"additionalProperties":true,"setVal":{
+		"full":["$concat","${first}"," ","${last}"],
+		"tags":["$concat","${a}","${b}"],
+		"code":["$upper","${first}"],
+		"slug":["$lower","${last}"],
+		"nick":["$coalesce","${missing}","${first}"],
+		"created":["$now","2006"]
+	}}`)
+	input := map[string]any{"first": "John", "last": "DOE", "a": []any{"x"}, "b": []any{"y", "z"}}
+	if err := sc.Validate(input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if input["full"] != "John DOE" || input["code"] != "JOHN" || input["slug"] != "doe" || input["nick"] != "John" {
+		t.Errorf("unexpected values: %v", input)
+	}
+	if tags, ok := input["tags"].([]any); !ok || len(tags) != 3 || tags[2] != "z" {
+		t.Errorf("unexpected tags: %v", input["tags"])
+	}
+	if created, ok := input["created"].(string); !ok || len(created) != 4 {
+		t.Errorf("unexpected created: %v", input["created"])
+	}
+}
+
+func TestUniqueItemsDeepEqual(t *testing.T) {
+	sc := mustSchema(t, `{"uniqueItems":true}`)
+	if err := sc.Validate(`[{"a":1,"b":[1,2]},{"a":1,"b":[2,1]},"x",1]`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := sc.ValidateError(`[{"a":1,"b":{"c":true}},"x",{"b":{"c":true},"a":1}]`)
+	if len(errs) != 1 || errs[0].Info != " items should be unique, index 0 and 2 are equal" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	if errs := sc.ValidateError(`[1,"1",2,1]`); len(errs) != 1 || errs[0].Info != " items should be unique, index 0 and 3 are equal" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestRefLocalDefinition(t *testing.T) {
+	sc := mustSchema(t, `{
+		"definitions":{"name":{"type":"string","maxLength":3},"a/b":{"type":"number"}},
+		"properties":{
+			"n":{"$ref":"#/definitions/name"},
+			"m":{"$ref":"#/definitions/a~1b"},
+			"r":{"$ref":"https://example.com/s.json"},
+			"o":{"$ref":"other.json#/x"}
+		}
+	}`)
+	if err := sc.Validate(map[string]any{"n": "abc", "m": 1.0}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := sc.ValidateError(map[string]any{"n": "toolong", "m": "s"})
+	if len(errs) != 2 {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	if err := sc.Validate(map[string]any{"r": 1.0, "o": "x"}); err != nil {
+		t.Fatalf("remote refs should be ignored, got %v", err)
+	}
+
+	missing := mustSchema(t, `{"properties":{"x":{"$ref":"#/definitions/missing"},"c":{"constVal":{"$ref":"#/nowhere"}}}}`)
+	errs = missing.ValidateError(map[string]any{"x": 1.0})
+	if len(errs) != 1 || errs[0].Path != "$.x" || errs[0].Info != "cannot resolve $ref: #/definitions/missing" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+
+	mustSchema(t, `{"anyOf":[{"type":"object"}],"properties":{"b":{"$ref":"#/anyOf/0"}}}`)
+	composed := mustSchema(t, `{"properties":{"a":{"anyOf":[{"type":"string"},{"type":"number"}]},"b":{"$ref":"#/properties/a/anyOf/1"}}}`)
+	if err := composed.Validate(map[string]any{"b": 1.0}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := composed.Validate(map[string]any{"b": "s"}); err == nil {
+		t.Fatal("expected #/properties/a/anyOf/1 to be applied")
+	}
+}
+
+func TestDefinitionsTable(t *testing.T) {
+	sc := mustSchema(t, `{
+		"$defs":{
+			"a":{"$ref":"#/$defs/b"},
+			"b":{"$ref":"#/definitions/c/$defs/d"}
+		},
+		"definitions":{"c":{"$defs":{"d":{"type":"string"}}}},
+		"properties":{"x":{"$ref":"#/$defs/a"}}
+	}`)
+	for _, ref := range []string{"#/$defs/a", "#/$defs/b", "#/definitions/c", "#/definitions/c/$defs/d"} {
+		if sc.Definition(ref) == nil {
+			t.Errorf("definition %s not indexed", ref)
+		}
+	}
+	if err := sc.Validate(map[string]any{"x": "ok"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := sc.ValidateError(map[string]any{"x": 1.0})
+	if len(errs) != 1 || errs[0].Path != "$.x" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+
+	_, err := jsonschema.NewSchemaFromJSON([]byte(`{
+		"definitions":{"a":{"$ref":"#/definitions/b"},"b":{"$ref":"#/definitions/a"}},
+		"properties":{"x":{"$ref":"#/definitions/a"}}
+	}`))
+	if err == nil {
+		t.Fatal("expected cyclic $ref error")
+	}
+
+	if _, err := jsonschema.NewSchemaFromJSON([]byte(`{
+		"definitions":{"node":{"properties":{"next":{"$ref":"#/definitions/node"}}}},
+		"properties":{"head":{"$ref":"#/definitions/node"}}
+	}`)); err != nil {
+		t.Fatalf("recursive schema through properties should compile: %v", err)
+	}
+}
@@ -432,8 +432,8 @@ func valueFloatByReflect(v reflect.Value) (float64, bool) {
 		return float64(v.Int()), true
 	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
 		return float64(v.Uint()), true
-	case reflect.Float32:
-		return float64(v.Float()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
 	}
 	return 0, false
 }
@@ -476,13 +476,7 @@ func (enums *Enums) Validate(c *ValidateCtx, value any) {
 		return
 	}
 	for _, e := range enums.Val {
-		if e == value {
-			return
-		}
-	}
-
-	for _, e := range enums.Val {
-		if Equal(e, value) {
+		if equalValue(e, value) {
 			return
 		}
 	}
@@ -518,7 +512,7 @@ func (r *Required) Validate(c *ValidateCtx, value any) {
 	for _, key := range r.Val {
 		if _, ok := m[key]; !ok {
 			c.AddError(Error{
-				Path: appendString(r.Path, ".", key),
+				Path: keyPath(r.Path, key),
 				Info: "field is required",
 			})
 		}
@@ -550,14 +544,14 @@ func (r *Required) validateStruct(c *ValidateCtx, v reflect.Value) {
 			case reflect.Ptr:
 				if fv.IsNil() {
 					c.AddError(Error{
-						Path: appendString(r.Path, ".", name),
+						Path: keyPath(r.Path, name),
 						Info: "field is required",
 					})
 				}
 			case reflect.String:
 				if fv.String() == "" {
 					c.AddError(Error{
-						Path: appendString(r.Path, ".", name),
+						Path: keyPath(r.Path, name),
 						Info: "field is required",
 					})
 				}
@@ -609,20 +603,69 @@ func NewRequired(i any, path string, parent Validator) (Validator, error) {
 type Items struct {
 	Val             *ArrProp
 	Path            string
-	additionalItems Validator
+	elemPath        string
+	parentPath      string
+	tuple           []Validator
+	additionalItems *additionalItems
 }
 
 func (item *Items) validateStruct(c *ValidateCtx, val any) {
 	v := reflect.ValueOf(val)
 	switch v.Kind() {
-	case reflect.Slice:
-
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		item.validateStruct(c, v.Elem().Interface())
+	case reflect.Slice, reflect.Array:
 		for i := 0; i < v.Len(); i++ {
 			vi := v.Index(i)
 			if vi.CanInterface() {
-				item.Val.Validate(c, vi.Interface())
+				item.validateAt(c, i, vi.Interface())
 			}
 		}
+		item.validateLength(c, v.Len())
+	}
+}
+
+// validateAt validates the element at idx against the items schema, or
+// against the tuple entry and additionalItems when items is an array.
+func (item *Items) validateAt(c *ValidateCtx, idx int, value any) {
+	if item.tuple == nil {
+		item.validateElem(c, idx, item.Val, value)
+		return
+	}
+	if idx < len(item.tuple) {
+		item.tuple[idx].Validate(c, value)
+		return
+	}
+	if item.additionalItems != nil && item.additionalItems.validator != nil {
+		item.validateElem(c, idx, item.additionalItems.validator, value)
+	}
+}
+
+func (item *Items) validateLength(c *ValidateCtx, n int) {
+	if item.tuple == nil || item.additionalItems == nil || item.additionalItems.enable {
+		return
+	}
+	if n > len(item.tuple) {
+		c.AddErrorInfo(item.parentPath, "additional items are not allowed, max length is "+strconv.Itoa(len(item.tuple)))
+	}
+}
+
+// validateElem validates one element and rewrites the "[*]" of the
+// element path in the produced errors to the element index.
+func (item *Items) validateElem(c *ValidateCtx, idx int, vad Validator, value any) {
+	start := len(c.errors)
+	vad.Validate(c, value)
+	if len(c.errors) == start {
+		return
+	}
+	indexed := appendString(item.parentPath, "[", strconv.Itoa(idx), "]")
+	for i := start; i < len(c.errors); i++ {
+		if strings.HasPrefix(c.errors[i].Path, item.elemPath) {
+			c.errors[i].Path = indexed + c.errors[i].Path[len(item.elemPath):]
+		}
 	}
 }
 
@@ -635,19 +678,20 @@ func (i *Items) Validate(c *ValidateCtx, value any) {
 		i.validateStruct(c, value)
 		return
 	}
-	for _, item := range arr {
-		for _, validator := range i.Val.Val {
-			if validator.Val != nil {
-				validator.Val.Validate(c, item)
-			}
-		}
+	for idx, item := range arr {
+		i.validateAt(c, idx, item)
 	}
+	i.validateLength(c, len(arr))
 }
 
 func NewItems(i any, path string, parent Validator) (Validator, error) {
+	parentPath := strings.TrimSuffix(path, "[*]")
+	if arr, ok := i.([]any); ok {
+		return newTupleItems(arr, path, parentPath, parent)
+	}
 	m, ok := i.(map[string]any)
 	if !ok {
-		return nil, fmt.Errorf("cannot create items with not object type: %v,path:%s", desc(i), path)
+		return nil, fmt.Errorf("cannot create items with not object or array type: %v,path:%s", desc(i), path)
 	}
 	p, err := NewProp(m, path)
 	if err != nil {
@@ -655,18 +699,57 @@ func NewItems(i any, path string, parent Validator) (Validator, error) {
 	}
 	p.(*ArrProp).Path = path + "[*]"
 	return &Items{
-		Val:  p.(*ArrProp),
-		Path: path + "[*]",
+		Val:        p.(*ArrProp),
+		Path:       path + "[*]",
+		elemPath:   path,
+		parentPath: parentPath,
 	}, nil
 }
 
+func newTupleItems(arr []any, path, parentPath string, parent Validator) (Validator, error) {
+	items := &Items{
+		Path:       path + "[*]",
+		elemPath:   path,
+		parentPath: parentPath,
+		tuple:      make([]Validator, 0, len(arr)),
+	}
+	for idx, v := range arr {
+		p, err := NewProp(v, appendString(parentPath, "[", strconv.Itoa(idx), "]"))
+		if err != nil {
+			return nil, fmt.Errorf("items index:%d is invalid:%w", idx, err)
+		}
+		items.tuple = append(items.tuple, p)
+	}
+	if ap, ok := parent.(*ArrProp); ok {
+		if additional, ok := ap.Get("additionalItems").(*additionalItems); ok {
+			items.additionalItems = additional
+		}
+	}
+	return items, nil
+}
+
 type additionalItems struct {
+	enable    bool
+	validator Validator
 }
 
 func (a *additionalItems) Validate(c *ValidateCtx, value any) {
 
 }
 
+func NewAdditionalItems(i any, path string, parent Validator) (Validator, error) {
+	switch i := i.(type) {
+	case bool:
+		return &additionalItems{enable: i}, nil
+	default:
+		vad, err := NewProp(i, path+"[*]")
+		if err != nil {
+			return nil, err
+		}
+		return &additionalItems{enable: true, validator: vad}, nil
+	}
+}
+
 type MultipleOf struct {
 	Val  float64
 	Path string
@@ -770,22 +853,22 @@ func NewMinB64DLength(i any, path string, parent Validator) (Validator, error) {
 
 type constValidator struct {
 	Path string
-	V    string
+	V    any
 }
 
 func (c2 constValidator) Validate(c *ValidateCtx, value any) {
-	if StringOf(value) == c2.V {
+	if equalValue(value, c2.V) {
 		return
 	}
 	c.AddError(Error{
 		Path: c2.Path,
-		Info: "value is invalid , expected: " + c2.V,
+		Info: "value is invalid , expected: " + StringOf(c2.V),
 	})
 }
 
 func NewConst(i any, path string, parent Validator) (Validator, error) {
 	return &constValidator{
 		Path: path,
-		V:    StringOf(i),
+		V:    i,
 	}, nil
 }
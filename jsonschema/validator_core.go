@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/oarkflow/json/sjson"
 )
@@ -14,6 +15,7 @@ func init() {
 	RegisterValidator("properties", NewProperties(false))
 
 	RegisterValidator("items", NewItems)
+	RegisterValidator("additionalItems", NewAdditionalItems)
 	RegisterValidator("anyOf", NewAnyOf)
 	RegisterValidator("if", NewIf)
 	RegisterValidator("else", NewElse)
@@ -59,6 +61,7 @@ var priorities = map[string]int{
 	"if":         1,
 	"required":   2,
 	"properties": 1,
+	"items":      1,
 	"maximum":    1,
 	"minimum":    1,
 }
@@ -220,7 +223,7 @@ func (p *Properties) GValidate(ctx *ValidateCtx, val *sjson.Result) {
 		vad := p.properties[key.Str]
 		if vad == nil {
 			if !p.EnableUnknownField {
-				ctx.AddErrorInfo(p.Path+"."+key.Str, "unknown field")
+				ctx.AddErrorInfo(keyPath(p.Path, key.Str), "unknown field")
 				return true
 			}
 			return true
@@ -240,7 +243,7 @@ func (p *Properties) Validate(c *ValidateCtx, value any) {
 			if pv == nil {
 				if !p.EnableUnknownField {
 					c.AddError(Error{
-						Path: appendString(p.Path, ".", k),
+						Path: keyPath(p.Path, k),
 						Info: "unknown field",
 					})
 					continue
@@ -257,12 +260,12 @@ func (p *Properties) Validate(c *ValidateCtx, value any) {
 		}
 
 		for key, val := range p.constVals {
-			m[key] = val.Val
+			m[key] = copyValue(val.Val)
 		}
 
 		for key, val := range p.defaultVals {
 			if _, ok := m[key]; !ok {
-				m[key] = val.Val
+				m[key] = copyValue(val.Val)
 				pv, _ := p.properties[key]
 				if pv != nil {
 
@@ -362,7 +365,7 @@ func (p *Properties) validateStruct(c *ValidateCtx, rv reflect.Value) {
 				vad.Validate(c, val.Interface())
 			} else {
 				if !p.EnableUnknownField {
-					c.AddErrorInfo(p.Path+"."+key.String(), "unknown filed")
+					c.AddErrorInfo(keyPath(p.Path, key.String()), "unknown filed")
 					return
 				}
 				if p.additionalProperties != nil {
@@ -395,7 +398,7 @@ func NewProperties(enableUnKnownFields bool) NewValidatorFunc {
 			EnableUnknownField: enableUnKnownFields,
 		}
 		for key, val := range m {
-			vad, err := NewProp(val, appendString(path, ".", key))
+			vad, err := NewProp(val, keyPath(path, key))
 			if err != nil {
 				return nil, err
 			}
@@ -502,14 +505,49 @@ var newError NewValidatorFunc = func(i any, path string, parent Validator) (Vali
 }
 
 type deleteValidator struct {
-	deletes []string
+	deletes [][]string
 }
 
 func (d *deleteValidator) Validate(c *ValidateCtx, value any) {
 	switch m := value.(type) {
 	case map[string]any:
-		for _, key := range d.deletes {
-			delete(m, key)
+		for _, path := range d.deletes {
+			deletePath(m, path)
+		}
+	}
+}
+
+// deletePath removes the value at the dotted path from v. A segment ending
+// with "*" matches every key with that prefix, and arrays are descended
+// element by element.
+func deletePath(v any, path []string) {
+	switch m := v.(type) {
+	case map[string]any:
+		seg := path[0]
+		prefix, wildcard := strings.CutSuffix(seg, "*")
+		if !wildcard {
+			if len(path) == 1 {
+				delete(m, seg)
+				return
+			}
+			if child, ok := m[seg]; ok {
+				deletePath(child, path[1:])
+			}
+			return
+		}
+		for key, child := range m {
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			if len(path) == 1 {
+				delete(m, key)
+				continue
+			}
+			deletePath(child, path[1:])
+		}
+	case []any:
+		for _, item := range m {
+			deletePath(item, path)
 		}
 	}
 }
@@ -519,11 +557,18 @@ var newDeleteValidator NewValidatorFunc = func(i any, path string, parent Valida
 	if !ok {
 		return nil, fmt.Errorf("new delete error, value should be array")
 	}
-	strs := []string{}
+	paths := make([][]string, 0, len(arr))
 	for _, v := range arr {
-		strs = append(strs, StringOf(v))
+		tokens, err := parseTokens(StringOf(v))
+		if err != nil {
+			return nil, fmt.Errorf("new delete error, invalid path %v: %w", v, err)
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+		paths = append(paths, tokens)
 	}
-	return &deleteValidator{deletes: strs}, nil
+	return &deleteValidator{deletes: paths}, nil
 }
 
 type childValidator struct {
@@ -550,7 +595,7 @@ var newChildrenValidator NewValidatorFunc = func(i any, path string, parent Vali
 	chv := &childValidator{children: map[string]Validator{}}
 	var err error
 	for key, val := range m {
-		chv.children[key], err = NewProp(val, path+"."+key)
+		chv.children[key], err = NewProp(val, keyPath(path, key))
 		if err != nil {
 			return nil, err
 		}
@@ -579,21 +624,29 @@ func (u *uniqueItems) Validate(c *ValidateCtx, value any) {
 	if !ok {
 		return
 	}
-	okMap := make(map[any]bool, len(arr))
-	for _, val := range arr {
-		if !isComparable(val) {
-			c.AddErrorInfo(u.path, " items should be comparable type,like [ string boolean number ]")
-			return
+	seen := make(map[any]int, len(arr))
+	for i, val := range arr {
+		if isComparable(val) {
+			if j, exist := seen[val]; exist {
+				u.addDuplicate(c, j, i)
+				return
+			}
+			seen[val] = i
+			continue
 		}
-		_, _exist := okMap[val]
-		if _exist {
-			c.AddErrorInfo(u.path, " items should be unique")
-			return
+		for j := 0; j < i; j++ {
+			if !isComparable(arr[j]) && equalValue(arr[j], val) {
+				u.addDuplicate(c, j, i)
+				return
+			}
 		}
-		okMap[val] = true
 	}
 }
 
+func (u *uniqueItems) addDuplicate(c *ValidateCtx, first, second int) {
+	c.AddErrorInfo(u.path, fmt.Sprintf(" items should be unique, index %d and %d are equal", first, second))
+}
+
 var newUniqueItemValidator NewValidatorFunc = func(i any, path string, parent Validator) (Validator, error) {
 	unique, ok := i.(bool)
 	if !ok {
@@ -616,11 +669,11 @@ type maxItems struct {
 }
 
 func (m *maxItems) Validate(c *ValidateCtx, value any) {
-	arr, ok := value.([]any)
+	n, ok := lengthOfArray(value)
 	if !ok {
 		return
 	}
-	if len(arr) > m.val {
+	if n > m.val {
 		c.AddErrorInfo(m.path, " max length is "+strconv.Itoa(m.val))
 	}
 }
@@ -639,11 +692,11 @@ type minItems struct {
 }
 
 func (m *minItems) Validate(c *ValidateCtx, value any) {
-	arr, ok := value.([]any)
+	n, ok := lengthOfArray(value)
 	if !ok {
 		return
 	}
-	if len(arr) < m.val {
+	if n < m.val {
 		c.AddErrorInfo(m.path, " min length is "+strconv.Itoa(m.val))
 	}
 }
@@ -656,6 +709,24 @@ var newMinItems NewValidatorFunc = func(i any, path string, parent Validator) (V
 	return &minItems{path: path, val: int(val)}, nil
 }
 
+func lengthOfArray(value any) (int, bool) {
+	if arr, ok := value.([]any); ok {
+		return len(arr), true
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
 func copyValue(v any) any {
 	switch vv := v.(type) {
 	case string, float64, bool:
@@ -676,7 +747,7 @@ func copyValue(v any) any {
 
 		return nil
 	}
-	return nil
+	return v
 }
 
 type exclusiveMaximum struct {
@@ -11,6 +11,10 @@ const (
 
 type AnyOf []Validator
 
+func (a AnyOf) GetChild(path string) Validator {
+	return childAt(a, path)
+}
+
 func (a AnyOf) Validate(c *ValidateCtx, value any) {
 	allErrs := []Error{}
 	for _, validator := range a {
@@ -143,6 +147,10 @@ func NewNot(i any, path string, parent Validator) (Validator, error) {
 
 type AllOf []Validator
 
+func (a AllOf) GetChild(path string) Validator {
+	return childAt(a, path)
+}
+
 func (a AllOf) Validate(c *ValidateCtx, value any) {
 	for _, validator := range a {
 		validator.Validate(c, value)
@@ -182,7 +190,7 @@ func (d *Dependencies) Validate(c *ValidateCtx, value any) {
 				_, ok = m[val]
 				if !ok {
 					c.AddErrors(Error{
-						Path: appendString(d.Path, ".", val),
+						Path: keyPath(d.Path, val),
 						Info: "is required",
 					})
 				}
@@ -242,7 +250,7 @@ func (k *KeyMatch) Validate(c *ValidateCtx, value any) {
 		target := m[key]
 		if target != want {
 			c.AddError(Error{
-				Path: appendString(k.Path, ".", key),
+				Path: keyPath(k.Path, key),
 				Info: fmt.Sprintf("value must be %v", want),
 			})
 		}
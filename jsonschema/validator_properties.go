@@ -90,6 +90,10 @@ func NewMaxProperties(i any, path string, parent Validator) (Validator, error) {
 
 type OneOf []Validator
 
+func (a OneOf) GetChild(path string) Validator {
+	return childAt(a, path)
+}
+
 func (a OneOf) Validate(c *ValidateCtx, value any) {
 	allErrs := []Error{}
 	for _, validator := range a {
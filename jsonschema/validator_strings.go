@@ -57,6 +57,7 @@ var formats = map[string]FormatValidateFunc{
 	"uri-reference":         wrapValidateFunc(isValidURIRef),
 	"uri-template":          wrapValidateFunc(isValidURITemplate),
 	"phone":                 wrapValidateFunc(isValidPhone),
+	"uuid":                  wrapValidateFunc(isValidUUID),
 }
 
 func AddFormatValidateFunc(name string, f FormatValidateFunc) {
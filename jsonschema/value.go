@@ -96,7 +96,7 @@ func parseValue(i any) (Value, error) {
 		return &Const{Val: i}, nil
 	case []any:
 		vv := i.([]any)
-		if len(vv) > 1 {
+		if len(vv) > 0 {
 			str := StringOf(vv[0])
 			if len(str) > 0 && str[0] == '$' {
 				funcName := str[1:]
@@ -2,18 +2,23 @@ package json
 
 import (
 	"encoding/json"
+	"sync/atomic"
 )
 
 type Marshaler func(any) ([]byte, error)
 
 var (
-	marshaler Marshaler
+	currentMarshaler atomic.Value
 )
 
 func DefaultMarshaler() {
-	marshaler = json.Marshal
+	SetMarshaler(json.Marshal)
 }
 
 func SetMarshaler(m Marshaler) {
-	marshaler = m
+	currentMarshaler.Store(m)
+}
+
+func marshaler(v any) ([]byte, error) {
+	return currentMarshaler.Load().(Marshaler)(v)
 }
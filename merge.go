@@ -0,0 +1,87 @@
+package json
+
+type ArrayMergeMode int
+
+const (
+	// ArrayReplace makes arrays in the overlay replace arrays in the base.
+	ArrayReplace ArrayMergeMode = iota
+	// ArrayConcat appends arrays in the overlay to arrays in the base.
+	ArrayConcat
+)
+
+// Merge deep-merges the overlay document into the base document. Objects
+// are merged key by key, and the overlay wins whenever the values are not
+// both objects (or both arrays in ArrayConcat mode). Numbers are kept as
+// written, so large integers survive the merge unchanged.
+func Merge(base, overlay []byte, mode ...ArrayMergeMode) ([]byte, error) {
+	var b, o any
+	if err := unmarshalNumber(base, &b); err != nil {
+		return nil, err
+	}
+	if err := unmarshalNumber(overlay, &o); err != nil {
+		return nil, err
+	}
+	m := ArrayReplace
+	if len(mode) > 0 {
+		m = mode[0]
+	}
+	return marshaler(mergeValue(b, o, m))
+}
+
+func mergeValue(base, overlay any, mode ArrayMergeMode) any {
+	switch ov := overlay.(type) {
+	case map[string]any:
+		bv, ok := base.(map[string]any)
+		if !ok {
+			return ov
+		}
+		for key, val := range ov {
+			if existing, ok := bv[key]; ok {
+				bv[key] = mergeValue(existing, val, mode)
+			} else {
+				bv[key] = val
+			}
+		}
+		return bv
+	case []any:
+		bv, ok := base.([]any)
+		if !ok || mode != ArrayConcat {
+			return ov
+		}
+		return append(bv, ov...)
+	}
+	return overlay
+}
+
+// ApplyMergePatch applies an RFC 7386 JSON Merge Patch to doc. Null values
+// in the patch delete keys, objects are merged recursively and any other
+// value replaces the target. Numbers are kept as written.
+func ApplyMergePatch(doc, patch []byte) ([]byte, error) {
+	var d, p any
+	if err := unmarshalNumber(doc, &d); err != nil {
+		return nil, err
+	}
+	if err := unmarshalNumber(patch, &p); err != nil {
+		return nil, err
+	}
+	return marshaler(mergePatchValue(d, p))
+}
+
+func mergePatchValue(target, patch any) any {
+	pm, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	tm, ok := target.(map[string]any)
+	if !ok {
+		tm = map[string]any{}
+	}
+	for key, val := range pm {
+		if val == nil {
+			delete(tm, key)
+			continue
+		}
+		tm[key] = mergePatchValue(tm[key], val)
+	}
+	return tm
+}
@@ -0,0 +1,308 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ApplyPatch applies an RFC 6902 JSON Patch document to doc and returns the
+// patched document. Operations are applied in order and the first failing
+// operation aborts the whole patch. Numbers are kept as written and test
+// compares them by numeric value.
+func ApplyPatch(doc []byte, patch []byte) ([]byte, error) {
+	var root any
+	if err := unmarshalNumber(doc, &root); err != nil {
+		return nil, err
+	}
+	var ops []map[string]any
+	if err := unmarshalNumber(patch, &ops); err != nil {
+		return nil, err
+	}
+	for i, op := range ops {
+		var err error
+		root, err = applyPatchOp(root, op)
+		if err != nil {
+			return nil, fmt.Errorf("json patch: operation %d: %w", i, err)
+		}
+	}
+	return marshaler(root)
+}
+
+func applyPatchOp(root any, op map[string]any) (any, error) {
+	name, _ := op["op"].(string)
+	path, err := patchPointer(op, "path")
+	if err != nil {
+		return nil, err
+	}
+	value, hasValue := op["value"]
+	switch name {
+	case "add", "replace", "test":
+		if !hasValue {
+			return nil, fmt.Errorf("%s requires a value", name)
+		}
+	}
+	switch name {
+	case "add":
+		return patchAdd(root, path, value)
+	case "remove":
+		root, _, err = patchRemove(root, path)
+		return root, err
+	case "replace":
+		if _, err := patchGet(root, path); err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return value, nil
+		}
+		return patchAt(root, path, func(parent any, key string) (any, error) {
+			switch p := parent.(type) {
+			case map[string]any:
+				p[key] = value
+				return p, nil
+			case []any:
+				idx, err := patchIndex(key, len(p))
+				if err != nil {
+					return nil, err
+				}
+				p[idx] = value
+				return p, nil
+			}
+			return nil, fmt.Errorf("cannot replace %q in a scalar value", key)
+		})
+	case "move", "copy":
+		from, err := patchPointer(op, "from")
+		if err != nil {
+			return nil, err
+		}
+		if name == "move" {
+			if len(from) < len(path) && reflect.DeepEqual(from, path[:len(from)]) {
+				return nil, errors.New("cannot move a value into one of its children")
+			}
+			root, value, err = patchRemove(root, from)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			value, err = patchGet(root, from)
+			if err != nil {
+				return nil, err
+			}
+			value = deepCopy(value)
+		}
+		return patchAdd(root, path, value)
+	case "test":
+		current, err := patchGet(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !patchEqual(current, value) {
+			return nil, fmt.Errorf("test failed at %q", op["path"])
+		}
+		return root, nil
+	}
+	return nil, fmt.Errorf("unknown op %q", name)
+}
+
+func patchAdd(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return patchAt(root, path, func(parent any, key string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			p[key] = value
+			return p, nil
+		case []any:
+			if key == "-" {
+				return append(p, value), nil
+			}
+			idx, err := patchIndex(key, len(p)+1)
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, nil)
+			copy(p[idx+1:], p[idx:])
+			p[idx] = value
+			return p, nil
+		}
+		return nil, fmt.Errorf("cannot add %q to a scalar value", key)
+	})
+}
+
+func patchRemove(root any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+	var removed any
+	root, err := patchAt(root, path, func(parent any, key string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			val, ok := p[key]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", key)
+			}
+			removed = val
+			delete(p, key)
+			return p, nil
+		case []any:
+			idx, err := patchIndex(key, len(p))
+			if err != nil {
+				return nil, err
+			}
+			removed = p[idx]
+			return append(p[:idx], p[idx+1:]...), nil
+		}
+		return nil, fmt.Errorf("cannot remove %q from a scalar value", key)
+	})
+	return root, removed, err
+}
+
+// patchAt walks node along path and calls fn with the parent of the last
+// token. The parent returned by fn is stored back into its own parent, so
+// fn may return a reallocated array.
+func patchAt(node any, path []string, fn func(parent any, key string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+	child, err := patchChild(node, path[0])
+	if err != nil {
+		return nil, err
+	}
+	child, err = patchAt(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	switch p := node.(type) {
+	case map[string]any:
+		p[path[0]] = child
+	case []any:
+		idx, _ := strconv.Atoi(path[0])
+		p[idx] = child
+	}
+	return node, nil
+}
+
+func patchGet(node any, path []string) (any, error) {
+	for _, key := range path {
+		var err error
+		node, err = patchChild(node, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func patchChild(node any, key string) (any, error) {
+	switch p := node.(type) {
+	case map[string]any:
+		val, ok := p[key]
+		if !ok {
+			return nil, fmt.Errorf("path %q does not exist", key)
+		}
+		return val, nil
+	case []any:
+		idx, err := patchIndex(key, len(p))
+		if err != nil {
+			return nil, err
+		}
+		return p[idx], nil
+	}
+	return nil, fmt.Errorf("cannot index %q in a scalar value", key)
+}
+
+func patchIndex(key string, n int) (int, error) {
+	if key == "" || strings.IndexFunc(key, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return 0, fmt.Errorf("invalid array index %q", key)
+	}
+	idx, err := strconv.Atoi(key)
+	if err != nil || idx >= n || (len(key) > 1 && key[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", key)
+	}
+	return idx, nil
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func patchPointer(op map[string]any, field string) ([]string, error) {
+	ptr, ok := op[field].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing %q", field)
+	}
+	if ptr == "" {
+		return nil, nil
+	}
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("invalid pointer %q", ptr)
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, tkn := range tokens {
+		tokens[i] = pointerUnescaper.Replace(tkn)
+	}
+	return tokens, nil
+}
+
+func deepCopy(v any) any {
+	switch vv := v.(type) {
+	case map[string]any:
+		dst := make(map[string]any, len(vv))
+		for key, val := range vv {
+			dst[key] = deepCopy(val)
+		}
+		return dst
+	case []any:
+		dst := make([]any, len(vv))
+		for i, val := range vv {
+			dst[i] = deepCopy(val)
+		}
+		return dst
+	}
+	return v
+}
+
+// patchEqual reports whether a and b are equal JSON values, comparing
+// numbers by value so 1 and 1.0 match.
+func patchEqual(a, b any) bool {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, val := range av {
+			other, ok := bv[key]
+			if !ok || !patchEqual(val, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !patchEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, okx := new(big.Rat).SetString(string(av))
+		y, oky := new(big.Rat).SetString(string(bv))
+		if !okx || !oky {
+			return av == bv
+		}
+		return x.Cmp(y) == 0
+	}
+	return a == b
+}
@@ -2,18 +2,23 @@ package json
 
 import (
 	"encoding/json"
+	"sync/atomic"
 )
 
 type Unmarshaler func([]byte, any) error
 
 var (
-	unmarshaler Unmarshaler
+	currentUnmarshaler atomic.Value
 )
 
 func DefaultUnmarshaler() {
-	unmarshaler = json.Unmarshal
+	SetUnmarshaler(json.Unmarshal)
 }
 
 func SetUnmarshaler(m Unmarshaler) {
-	unmarshaler = m
+	currentUnmarshaler.Store(m)
+}
+
+func unmarshaler(data []byte, v any) error {
+	return currentUnmarshaler.Load().(Unmarshaler)(data, v)
 }